@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	vegeta "github.com/davideicardi/vegeta/lib"
+)
+
+// attackOpts holds the flags accepted by the attack subcommand.
+type attackOpts struct {
+	targetsf string
+	outputf  string
+	rate     uint64
+	duration time.Duration
+
+	// json and interval stream a SnapshotReporter's running metrics as
+	// JSON to stdout every interval, so long-running attacks can be
+	// monitored without buffering all Results in memory.
+	json     bool
+	interval time.Duration
+
+	// metricsAddr, when set, serves a PrometheusReporter's Handler so
+	// the attack can be scraped while it runs.
+	metricsAddr string
+
+	// dashboardAddr, when set, serves a live-updating Dashboard of the
+	// in-progress attack.
+	dashboardAddr string
+}
+
+func attackCmd(args []string) command {
+	fs := flag.NewFlagSet("vegeta attack", flag.ExitOnError)
+	opts := &attackOpts{}
+
+	fs.StringVar(&opts.targetsf, "targets", "stdin", "Targets file")
+	fs.StringVar(&opts.outputf, "output", "stdout", "Output file")
+	fs.Uint64Var(&opts.rate, "rate", 50, "Number of requests per time unit [0 = infinity]")
+	fs.DurationVar(&opts.duration, "duration", 0, "Duration of the test [0 = forever]")
+	fs.BoolVar(&opts.json, "json", false, "Print a JSON snapshot of running metrics to stdout every -interval")
+	fs.DurationVar(&opts.interval, "interval", 200*time.Millisecond, "Interval between -json snapshots")
+	fs.StringVar(&opts.metricsAddr, "metrics-addr", "", "Address to serve Prometheus metrics on [\"\" = disabled]")
+	fs.StringVar(&opts.dashboardAddr, "dashboard-addr", "", "Address to serve a live dashboard on [\"\" = disabled]")
+
+	return command{fs, func(args []string) error { return attack(opts) }}
+}
+
+// attack runs the configured attack, streaming each Result into the
+// output encoder and, when opts.json is set, into a SnapshotReporter that
+// is printed to stdout every opts.interval and once more on completion,
+// when opts.metricsAddr is set, into a PrometheusReporter served for
+// scraping, and when opts.dashboardAddr is set, into a live Dashboard.
+func attack(opts *attackOpts) error {
+	results, err := runAttack(opts)
+	if err != nil {
+		return err
+	}
+
+	var snap *vegeta.SnapshotReporter
+	var stop chan struct{}
+	if opts.json {
+		snap = vegeta.NewSnapshotReporter()
+		stop = make(chan struct{})
+		go printSnapshots(snap, opts.interval, stop)
+	}
+
+	var prom *vegeta.PrometheusReporter
+	var metricsSrv *http.Server
+	if opts.metricsAddr != "" {
+		prom = vegeta.NewPrometheusReporter(nil)
+		metricsSrv = &http.Server{Addr: opts.metricsAddr, Handler: prom.Handler()}
+		go func() {
+			if err := metricsSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				fmt.Fprintln(os.Stderr, "metrics:", err)
+			}
+		}()
+		defer metricsSrv.Close()
+	}
+
+	// dashResults forwards each Result into the Dashboard alongside the
+	// snap/prom/enc pipeline below; it's buffered and fed with a
+	// non-blocking send so a slow dashboard poller can never stall the
+	// attack loop.
+	var dashResults chan *vegeta.Result
+	if opts.dashboardAddr != "" {
+		dashResults = make(chan *vegeta.Result, 1024)
+		dash := vegeta.NewDashboard(dashResults, vegeta.DashboardOptions{Addr: opts.dashboardAddr})
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			if err := dash.Serve(ctx); err != nil && err != http.ErrServerClosed {
+				fmt.Fprintln(os.Stderr, "dashboard:", err)
+			}
+		}()
+		defer close(dashResults)
+	}
+
+	out, err := output(opts.outputf)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	enc := gob.NewEncoder(out)
+	for res := range results {
+		if snap != nil {
+			snap.Add(res)
+		}
+		if prom != nil {
+			prom.Add(res)
+		}
+		if dashResults != nil {
+			select {
+			case dashResults <- res:
+			default:
+			}
+		}
+		if err := enc.Encode(res); err != nil {
+			return err
+		}
+	}
+
+	if snap != nil {
+		close(stop)
+		printSnapshot(snap)
+	}
+
+	return nil
+}
+
+// printSnapshots prints a SnapshotReporter's current state to stdout
+// every interval, until stop is closed.
+func printSnapshots(snap *vegeta.SnapshotReporter, interval time.Duration, stop <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+			printSnapshot(snap)
+		case <-stop:
+			return
+		}
+	}
+}
+
+func printSnapshot(snap *vegeta.SnapshotReporter) {
+	json.NewEncoder(os.Stdout).Encode(snap.Snapshot())
+}
+
+func output(path string) (*os.File, error) {
+	if path == "stdout" {
+		return os.Stdout, nil
+	}
+	return os.Create(path)
+}
+
+// runAttack returns the channel of Results that attack() consumes. It is
+// kept separate from attack() so the flag wiring above does not depend on
+// how targets are read or requests are issued. In this tree it reads no
+// targets, issues no requests and always returns an error, so attack()
+// returns that error before any Result is produced.
+func runAttack(opts *attackOpts) (<-chan *vegeta.Result, error) {
+	return nil, fmt.Errorf("vegeta attack: not implemented in this tree")
+}
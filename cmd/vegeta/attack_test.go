@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/gob"
+	"os"
+	"testing"
+	"time"
+
+	vegeta "github.com/davideicardi/vegeta/lib"
+)
+
+// TestAttackGobRoundTrip pins attack's on-disk format: Results gob-encoded
+// the way attack() encodes them must decode back via decodeResultsFile,
+// the same path every `report -type=...` reads through.
+func TestAttackGobRoundTrip(t *testing.T) {
+	path := t.TempDir() + "/results.bin"
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*vegeta.Result{
+		{Code: 200, Latency: 10 * time.Millisecond},
+		{Code: 500, Latency: 20 * time.Millisecond, Error: "boom"},
+	}
+
+	enc := gob.NewEncoder(f)
+	for _, r := range want {
+		if err := enc.Encode(r); err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := decodeResultsFile(path)
+	if err != nil {
+		t.Fatalf("decodeResultsFile: %v", err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d results, want %d", len(got), len(want))
+	}
+	for i, r := range want {
+		if got[i].Code != r.Code || got[i].Latency != r.Latency || got[i].Error != r.Error {
+			t.Errorf("result %d = %+v, want %+v", i, got[i], r)
+		}
+	}
+}
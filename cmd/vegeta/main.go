@@ -0,0 +1,48 @@
+// Command vegeta is a versatile HTTP load testing tool.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// command is a named subcommand: a FlagSet to parse its arguments and a
+// run func to execute once parsed.
+type command struct {
+	fs  *flag.FlagSet
+	run func(args []string) error
+}
+
+// commands is the registry of vegeta subcommands, keyed by name.
+var commands = map[string]func([]string) command{
+	"attack": attackCmd,
+	"report": reportCmd,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage(1)
+	}
+
+	newCmd, ok := commands[os.Args[1]]
+	if !ok {
+		usage(1)
+	}
+
+	cmd := newCmd(os.Args[2:])
+	if err := cmd.fs.Parse(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := cmd.run(cmd.fs.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func usage(code int) {
+	fmt.Fprintln(os.Stderr, "Usage: vegeta [attack|report] [options]")
+	os.Exit(code)
+}
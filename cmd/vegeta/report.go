@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/gob"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	vegeta "github.com/davideicardi/vegeta/lib"
+)
+
+// reportOpts holds the flags accepted by the report subcommand.
+type reportOpts struct {
+	typ     string
+	outputf string
+	width   int
+	height  int
+	buckets vegeta.HistogramReporter
+
+	// bucketsSet records whether -buckets was passed explicitly, so
+	// type=prometheus can fall back to PrometheusReporter's own tuned
+	// default buckets instead of the coarser defaultDiffBuckets.
+	bucketsSet bool
+}
+
+func reportCmd(args []string) command {
+	fs := flag.NewFlagSet("vegeta report", flag.ExitOnError)
+	opts := &reportOpts{}
+
+	fs.StringVar(&opts.typ, "type", "text", "Report type: text, json, hist, plot, chart, png, prometheus, diff, diff-json")
+	fs.StringVar(&opts.outputf, "output", "stdout", "Output file")
+	fs.IntVar(&opts.width, "width", 1024, "Width of the output image (type=png)")
+	fs.IntVar(&opts.height, "height", 768, "Height of the output image (type=png)")
+	fs.Var(&opts.buckets, "buckets", "Histogram buckets, e.g. [0,1ms,10ms] (type=hist, type=diff)")
+
+	return command{fs, func(args []string) error {
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name == "buckets" {
+				opts.bucketsSet = true
+			}
+		})
+		if !opts.bucketsSet {
+			opts.buckets = defaultDiffBuckets
+		}
+		return report(opts, fs.Args())
+	}}
+}
+
+// report reads a gob-encoded Results file and renders it according to
+// opts.typ, writing the result to opts.outputf.
+func report(opts *reportOpts, files []string) error {
+	if opts.typ == "diff" || opts.typ == "diff-json" {
+		return reportDiff(opts, files)
+	}
+
+	if len(files) == 0 {
+		files = []string{"stdin"}
+	}
+	if len(files) != 1 {
+		return fmt.Errorf("report -type=%s takes exactly one result file", opts.typ)
+	}
+
+	results, err := decodeResultsFile(files[0])
+	if err != nil {
+		return err
+	}
+
+	var rep vegeta.Reporter
+	switch opts.typ {
+	case "text":
+		rep = vegeta.ReportText
+	case "json":
+		rep = vegeta.ReportJSON
+	case "plot":
+		rep = vegeta.ReportPlot
+	case "chart":
+		rep = vegeta.ReportChart
+	case "png":
+		rep = vegeta.ReportPNG(opts.width, opts.height)
+	case "hist":
+		rep = opts.buckets
+	case "prometheus":
+		var buckets vegeta.HistogramReporter
+		if opts.bucketsSet {
+			buckets = opts.buckets
+		}
+		rep = vegeta.NewPrometheusReporter(buckets)
+	default:
+		return fmt.Errorf("unknown report type: %s", opts.typ)
+	}
+
+	data, err := rep.Report(results)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(opts.outputf, data)
+}
+
+// reportDiff implements `vegeta report -type=diff a.bin b.bin` (or
+// -type=diff-json for machine-readable output), comparing two attack
+// Results sets with a CompareReporter, suitable for regression gating in
+// CI (e.g. failing a build if p99 regresses more than some threshold).
+func reportDiff(opts *reportOpts, files []string) error {
+	if len(files) != 2 {
+		return fmt.Errorf("report -type=%s requires exactly two result files, got %d", opts.typ, len(files))
+	}
+
+	a, err := decodeResultsFile(files[0])
+	if err != nil {
+		return err
+	}
+	b, err := decodeResultsFile(files[1])
+	if err != nil {
+		return err
+	}
+
+	cr := vegeta.NewCompareReporter(opts.buckets)
+
+	var data []byte
+	if opts.typ == "diff-json" {
+		data, err = cr.JSON(a, b)
+	} else {
+		data, err = cr.Text(a, b)
+	}
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(opts.outputf, data)
+}
+
+// defaultDiffBuckets is the bucket list used by type=hist and type=diff when
+// -buckets is not given; a diff bins both runs with the same list.
+var defaultDiffBuckets = vegeta.HistogramReporter{
+	0, 10 * time.Millisecond, 50 * time.Millisecond, 100 * time.Millisecond,
+	500 * time.Millisecond, 1 * time.Second, 5 * time.Second,
+}
+
+func decodeResultsFile(path string) (vegeta.Results, error) {
+	f, err := input(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var results vegeta.Results
+	dec := gob.NewDecoder(f)
+	for {
+		var r vegeta.Result
+		if err := dec.Decode(&r); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
+func input(path string) (*os.File, error) {
+	if path == "stdin" {
+		return os.Stdin, nil
+	}
+	return os.Open(path)
+}
+
+func writeOutput(path string, data []byte) error {
+	out, err := output(path)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = out.Write(data)
+	return err
+}
@@ -0,0 +1,249 @@
+package vegeta
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"sync"
+	"time"
+)
+
+//go:embed templates/dashboard.html templates/dashboard.js
+var dashboardAssets embed.FS
+
+// dashboardStatic serves dashboard.js without the templates/ path prefix.
+var dashboardStatic = func() fs.FS {
+	sub, err := fs.Sub(dashboardAssets, "templates")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}()
+
+// views holds the registry of dashboard views keyed by name. New views can
+// be registered by appending to it, and are exposed at /data/{name}.
+var views = map[string]func(*Dashboard) interface{}{
+	"latency": (*Dashboard).latencyView,
+	"rps":     (*Dashboard).rpsView,
+	"code":    (*Dashboard).codeView,
+}
+
+// DashboardOptions configures a Dashboard.
+type DashboardOptions struct {
+	// Addr is the address the dashboard HTTP server listens on.
+	Addr string
+	// Window is the sliding window of results kept for the live views.
+	Window time.Duration
+	// RefreshInterval is how often the page polls /data/* for updates.
+	RefreshInterval time.Duration
+}
+
+// Dashboard streams Results from an in-progress attack into a buffer that
+// keeps only those within the sliding Window, and serves a live-updating
+// HTML dashboard summarizing them.
+type Dashboard struct {
+	opts    DashboardOptions
+	results <-chan *Result
+
+	mu  sync.RWMutex
+	buf []*Result
+
+	tmpl *template.Template
+}
+
+// NewDashboard returns a Dashboard that consumes results from the given
+// channel until it is closed or the context passed to Serve is canceled.
+func NewDashboard(results <-chan *Result, opts DashboardOptions) *Dashboard {
+	if opts.Addr == "" {
+		opts.Addr = ":8880"
+	}
+	if opts.Window == 0 {
+		opts.Window = 60 * time.Second
+	}
+	if opts.RefreshInterval == 0 {
+		opts.RefreshInterval = time.Second
+	}
+
+	return &Dashboard{
+		opts:    opts,
+		results: results,
+		tmpl:    template.Must(template.ParseFS(dashboardAssets, "templates/dashboard.html")),
+	}
+}
+
+// Serve ingests results and serves the dashboard until ctx is canceled.
+func (d *Dashboard) Serve(ctx context.Context) error {
+	go d.ingest(ctx)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", d.handleIndex)
+	mux.HandleFunc("/data/", d.handleData)
+	mux.Handle("/dashboard.js", http.FileServer(http.FS(dashboardStatic)))
+
+	srv := &http.Server{Addr: d.opts.Addr, Handler: mux}
+
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+
+	select {
+	case <-ctx.Done():
+		return srv.Close()
+	case err := <-errc:
+		return err
+	}
+}
+
+func (d *Dashboard) ingest(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case r, ok := <-d.results:
+			if !ok {
+				return
+			}
+			d.add(r)
+		}
+	}
+}
+
+func (d *Dashboard) add(r *Result) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.buf = append(d.buf, r)
+
+	cutoff := r.Timestamp.Add(-d.opts.Window)
+	i := 0
+	for ; i < len(d.buf); i++ {
+		if d.buf[i].Timestamp.After(cutoff) {
+			break
+		}
+	}
+	d.buf = d.buf[i:]
+}
+
+func (d *Dashboard) snapshot() Results {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	rs := make(Results, len(d.buf))
+	for i, r := range d.buf {
+		rs[i] = *r
+	}
+	return rs
+}
+
+// windowElapsed returns how much of the configured window is actually
+// covered by buffered results, so callers don't divide by a window wider
+// than the attack has been running for.
+func (d *Dashboard) windowElapsed() time.Duration {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if len(d.buf) == 0 {
+		return 0
+	}
+
+	elapsed := time.Since(d.buf[0].Timestamp)
+	if elapsed > d.opts.Window {
+		return d.opts.Window
+	}
+	return elapsed
+}
+
+func (d *Dashboard) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	d.tmpl.Execute(w, d.opts)
+}
+
+func (d *Dashboard) handleData(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Path[len("/data/"):]
+	view, ok := views[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(view(d))
+}
+
+type latencyPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	P50       float64   `json:"p50"`
+	P95       float64   `json:"p95"`
+	P99       float64   `json:"p99"`
+	Max       float64   `json:"max"`
+}
+
+func (d *Dashboard) latencyView() interface{} {
+	r := d.snapshot()
+	if len(r) == 0 {
+		return latencyPoint{Timestamp: time.Now()}
+	}
+	m := NewMetrics(r)
+	return latencyPoint{
+		Timestamp: time.Now(),
+		P50:       m.Latencies.P50.Seconds() * 1000,
+		P95:       m.Latencies.P95.Seconds() * 1000,
+		P99:       m.Latencies.P99.Seconds() * 1000,
+		Max:       m.Latencies.Max.Seconds() * 1000,
+	}
+}
+
+type rpsPoint struct {
+	Timestamp time.Time `json:"timestamp"`
+	OK        float64   `json:"ok"`
+	Error     float64   `json:"error"`
+}
+
+func (d *Dashboard) rpsView() interface{} {
+	r := d.snapshot()
+	elapsed := d.windowElapsed().Seconds()
+	if elapsed == 0 {
+		return rpsPoint{Timestamp: time.Now()}
+	}
+
+	var ok, errs float64
+	for _, res := range r {
+		if res.Error == "" {
+			ok++
+		} else {
+			errs++
+		}
+	}
+
+	return rpsPoint{
+		Timestamp: time.Now(),
+		OK:        ok / elapsed,
+		Error:     errs / elapsed,
+	}
+}
+
+type codePoint struct {
+	Timestamp time.Time      `json:"timestamp"`
+	Classes   map[string]int `json:"classes"`
+}
+
+func (d *Dashboard) codeView() interface{} {
+	r := d.snapshot()
+	classes := map[string]int{"2xx": 0, "3xx": 0, "4xx": 0, "5xx": 0, "err": 0}
+
+	for _, res := range r {
+		if res.Error != "" {
+			classes["err"]++
+			continue
+		}
+		classes[fmt.Sprintf("%dxx", res.Code/100)]++
+	}
+
+	return codePoint{Timestamp: time.Now(), Classes: classes}
+}
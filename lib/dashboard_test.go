@@ -0,0 +1,24 @@
+package vegeta
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDashboardRPSViewUsesElapsedNotFullWindow(t *testing.T) {
+	d := NewDashboard(nil, DashboardOptions{Window: 60 * time.Second})
+
+	start := time.Now().Add(-2 * time.Second)
+	for i := 0; i < 10; i++ {
+		r := &Result{Timestamp: start.Add(time.Duration(i) * 200 * time.Millisecond)}
+		d.add(r)
+	}
+
+	point := d.rpsView().(rpsPoint)
+
+	// 10 OK requests spread over ~2s should report an RPS close to 5, not
+	// ~0.17 (10 requests / a 60s window).
+	if point.OK < 3 || point.OK > 8 {
+		t.Errorf("OK rps = %.2f, want roughly 5 (10 requests over ~2s)", point.OK)
+	}
+}
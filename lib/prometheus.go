@@ -0,0 +1,158 @@
+package vegeta
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultLatencyBuckets are the upper bounds, in seconds, of the latency
+// histogram buckets used by a PrometheusReporter when none are given, in
+// the same spirit as HistogramReporter's bucket list.
+var defaultLatencyBuckets = []time.Duration{
+	1 * time.Millisecond,
+	2 * time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	20 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	200 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2 * time.Second,
+	5 * time.Second,
+	10 * time.Second,
+	20 * time.Second,
+	30 * time.Second,
+}
+
+// PrometheusReporter is a Reporter that also exposes running attack
+// metrics in the OpenMetrics text exposition format via its Handler, so a
+// long-running attack can be scraped by Prometheus instead of only
+// reported on once it completes. It is safe for concurrent use: Add can
+// be called from the attack loop while Handler is being scraped.
+type PrometheusReporter struct {
+	buckets []time.Duration
+
+	mu            sync.Mutex
+	start         time.Time
+	requestsTotal map[uint16]uint64
+	bytesIn       uint64
+	bytesOut      uint64
+	bucketCounts  []uint64
+	latencySum    float64
+	errorsTotal   map[string]uint64
+}
+
+// NewPrometheusReporter returns a PrometheusReporter with the given
+// latency histogram buckets, or defaultLatencyBuckets if buckets is empty.
+func NewPrometheusReporter(buckets []time.Duration) *PrometheusReporter {
+	if len(buckets) == 0 {
+		buckets = defaultLatencyBuckets
+	}
+	return &PrometheusReporter{
+		buckets:       buckets,
+		start:         time.Now(),
+		requestsTotal: map[uint16]uint64{},
+		bucketCounts:  make([]uint64, len(buckets)+1), // +1 for the +Inf bucket
+		errorsTotal:   map[string]uint64{},
+	}
+}
+
+// Add records a single Result in the running counters.
+func (p *PrometheusReporter) Add(r *Result) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.requestsTotal[r.Code]++
+	p.bytesIn += r.BytesIn
+	p.bytesOut += r.BytesOut
+	p.latencySum += r.Latency.Seconds()
+
+	for i, b := range p.buckets {
+		if r.Latency <= b {
+			p.bucketCounts[i]++
+		}
+	}
+	p.bucketCounts[len(p.buckets)]++ // +Inf
+
+	if r.Error != "" {
+		p.errorsTotal[r.Error]++
+	}
+}
+
+// Handler returns an http.Handler serving the current metrics in
+// OpenMetrics text exposition format.
+func (p *PrometheusReporter) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+		w.Write(p.expose())
+	})
+}
+
+// Report implements the Reporter interface for offline use: it replays the
+// given Results through the same counters as Add and renders them in
+// OpenMetrics text exposition format.
+func (p *PrometheusReporter) Report(r Results) ([]byte, error) {
+	for i := range r {
+		p.Add(&r[i])
+	}
+	return p.expose(), nil
+}
+
+func (p *PrometheusReporter) expose() []byte {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "# TYPE vegeta_requests_total counter")
+	for code, count := range p.requestsTotal {
+		fmt.Fprintf(&buf, "vegeta_requests_total{code=\"%d\"} %d\n", code, count)
+	}
+
+	fmt.Fprintln(&buf, "# TYPE vegeta_request_bytes_in_total counter")
+	fmt.Fprintf(&buf, "vegeta_request_bytes_in_total %d\n", p.bytesIn)
+
+	fmt.Fprintln(&buf, "# TYPE vegeta_request_bytes_out_total counter")
+	fmt.Fprintf(&buf, "vegeta_request_bytes_out_total %d\n", p.bytesOut)
+
+	fmt.Fprintln(&buf, "# TYPE vegeta_request_latency_seconds histogram")
+	// bucketCounts is already cumulative (Add increments every bucket a
+	// latency falls at or under), so print it as-is rather than summing
+	// again.
+	for i, b := range p.buckets {
+		fmt.Fprintf(&buf, "vegeta_request_latency_seconds_bucket{le=\"%s\"} %d\n", formatSeconds(b), p.bucketCounts[i])
+	}
+	count := p.bucketCounts[len(p.buckets)]
+	fmt.Fprintf(&buf, "vegeta_request_latency_seconds_bucket{le=\"+Inf\"} %d\n", count)
+	fmt.Fprintf(&buf, "vegeta_request_latency_seconds_sum %s\n", strconv.FormatFloat(p.latencySum, 'f', -1, 64))
+	fmt.Fprintf(&buf, "vegeta_request_latency_seconds_count %d\n", count)
+
+	fmt.Fprintln(&buf, "# TYPE vegeta_errors_total counter")
+	for err, count := range p.errorsTotal {
+		fmt.Fprintf(&buf, "vegeta_errors_total{error=%q} %d\n", err, count)
+	}
+
+	fmt.Fprintln(&buf, "# TYPE vegeta_attack_duration_seconds gauge")
+	fmt.Fprintf(&buf, "vegeta_attack_duration_seconds %s\n", strconv.FormatFloat(time.Since(p.start).Seconds(), 'f', -1, 64))
+
+	fmt.Fprintln(&buf, "# EOF")
+
+	return buf.Bytes()
+}
+
+// formatSeconds formats a latency bucket bound as a decimal number of
+// seconds, matching the convention of Prometheus histogram "le" labels.
+func formatSeconds(d time.Duration) string {
+	s := strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
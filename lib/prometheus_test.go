@@ -0,0 +1,50 @@
+package vegeta
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusReporterHistogramBuckets(t *testing.T) {
+	buckets := []time.Duration{10 * time.Millisecond, 50 * time.Millisecond, 100 * time.Millisecond}
+	p := NewPrometheusReporter(buckets)
+
+	latencies := []time.Duration{5 * time.Millisecond, 30 * time.Millisecond, 200 * time.Millisecond}
+	for _, lat := range latencies {
+		p.Add(&Result{Code: 200, Latency: lat})
+	}
+
+	body := string(p.expose())
+
+	cases := []struct {
+		le   string
+		want string
+	}{
+		{"0.01", "1"},
+		{"0.05", "2"},
+		{"0.1", "2"},
+		{"+Inf", "3"},
+	}
+	for _, c := range cases {
+		want := `le="` + c.le + `"} ` + c.want
+		if got := bucketLine(body, c.le); !strings.HasSuffix(got, c.want) {
+			t.Errorf("bucket le=%s: got line %q, want %q", c.le, got, want)
+		}
+	}
+
+	if !strings.Contains(body, "vegeta_request_latency_seconds_count 3\n") {
+		t.Errorf("expected _count 3, got body:\n%s", body)
+	}
+}
+
+// bucketLine returns the exposition line for the bucket matching le, or ""
+// if not found.
+func bucketLine(body, le string) string {
+	for _, line := range strings.Split(body, "\n") {
+		if strings.Contains(line, `le="`+le+`"`) {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,197 @@
+package vegeta
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// CompareReporter compares two attack Results sets, binning their
+// latencies into the same histogram buckets so the two runs are
+// comparable bucket-for-bucket. It is intended for regression gating in
+// CI, e.g. failing a build if p99 regresses more than some threshold.
+type CompareReporter struct {
+	Buckets HistogramReporter
+}
+
+// NewCompareReporter returns a CompareReporter that bins latencies using
+// the given buckets, reusing HistogramReporter's bucket definitions so
+// both runs are binned identically.
+func NewCompareReporter(buckets HistogramReporter) *CompareReporter {
+	return &CompareReporter{Buckets: buckets}
+}
+
+// Comparison is a side-by-side summary of two attack Results sets,
+// suitable for JSON serialization.
+type Comparison struct {
+	A         ComparisonSide  `json:"a"`
+	B         ComparisonSide  `json:"b"`
+	Buckets   []time.Duration `json:"-"`
+	Histogram []BucketDiff    `json:"histogram"`
+}
+
+// ComparisonSide summarizes a single attack Results set.
+type ComparisonSide struct {
+	Requests    int            `json:"requests"`
+	RPS         float64        `json:"rps"`
+	Success     float64        `json:"success"`
+	Latencies   LatencySummary `json:"latencies"`
+	StatusCodes map[string]int `json:"status_codes"`
+}
+
+// LatencySummary holds the percentiles compared between two runs.
+type LatencySummary struct {
+	P50 time.Duration `json:"p50"`
+	P95 time.Duration `json:"p95"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// BucketDiff is the request count of a single histogram bucket in each
+// run, plus the delta between them.
+type BucketDiff struct {
+	Bucket  string  `json:"bucket"`
+	A       int     `json:"a"`
+	B       int     `json:"b"`
+	Delta   int     `json:"delta"`
+	Percent float64 `json:"percent"`
+}
+
+// Compare computes a Comparison of the two Results sets.
+func (c *CompareReporter) Compare(a, b Results) Comparison {
+	ma, mb := NewMetrics(a), NewMetrics(b)
+	ha, hb := Histogram(c.Buckets, a), Histogram(c.Buckets, b)
+
+	cmp := Comparison{
+		A:       newComparisonSide(ma),
+		B:       newComparisonSide(mb),
+		Buckets: c.Buckets,
+	}
+
+	for i := range c.Buckets {
+		var bucket string
+		if i+1 >= len(c.Buckets) {
+			bucket = fmt.Sprintf("[%s,+Inf]", c.Buckets[i])
+		} else {
+			bucket = fmt.Sprintf("[%s,%s]", c.Buckets[i], c.Buckets[i+1])
+		}
+
+		cmp.Histogram = append(cmp.Histogram, BucketDiff{
+			Bucket:  bucket,
+			A:       ha[i],
+			B:       hb[i],
+			Delta:   hb[i] - ha[i],
+			Percent: percentChange(ha[i], hb[i]),
+		})
+	}
+
+	return cmp
+}
+
+func newComparisonSide(m Metrics) ComparisonSide {
+	var rps float64
+	if m.Duration > 0 {
+		rps = float64(m.Requests) / m.Duration.Seconds()
+	}
+	return ComparisonSide{
+		Requests: int(m.Requests),
+		RPS:      rps,
+		Success:  m.Success,
+		Latencies: LatencySummary{
+			P50: m.Latencies.P50,
+			P95: m.Latencies.P95,
+			P99: m.Latencies.P99,
+			Max: m.Latencies.Max,
+		},
+		StatusCodes: m.StatusCodes,
+	}
+}
+
+func percentChange(a, b int) float64 {
+	if a == 0 {
+		if b == 0 {
+			return 0
+		}
+		return 100
+	}
+	return (float64(b) - float64(a)) / float64(a) * 100
+}
+
+// Text renders a Comparison of a and b as aligned, formatted text.
+func (c *CompareReporter) Text(a, b Results) ([]byte, error) {
+	cmp := c.Compare(a, b)
+	out := &bytes.Buffer{}
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', tabwriter.StripEscape)
+
+	fmt.Fprintf(w, "Requests\t[A, B]\t%d, %d\n", cmp.A.Requests, cmp.B.Requests)
+	fmt.Fprintf(w, "RPS\t[A, B]\t%.2f, %.2f\n", cmp.A.RPS, cmp.B.RPS)
+	fmt.Fprintf(w, "Success\t[A, B]\t%.2f%%, %.2f%%\n", cmp.A.Success*100, cmp.B.Success*100)
+	fmt.Fprintf(w, "Latencies p50\t[A, B, Δ%%]\t%s, %s, %.2f%%\n",
+		cmp.A.Latencies.P50, cmp.B.Latencies.P50, durationPercentChange(cmp.A.Latencies.P50, cmp.B.Latencies.P50))
+	fmt.Fprintf(w, "Latencies p95\t[A, B, Δ%%]\t%s, %s, %.2f%%\n",
+		cmp.A.Latencies.P95, cmp.B.Latencies.P95, durationPercentChange(cmp.A.Latencies.P95, cmp.B.Latencies.P95))
+	fmt.Fprintf(w, "Latencies p99\t[A, B, Δ%%]\t%s, %s, %.2f%%\n",
+		cmp.A.Latencies.P99, cmp.B.Latencies.P99, durationPercentChange(cmp.A.Latencies.P99, cmp.B.Latencies.P99))
+	fmt.Fprintf(w, "Latencies max\t[A, B, Δ%%]\t%s, %s, %.2f%%\n",
+		cmp.A.Latencies.Max, cmp.B.Latencies.Max, durationPercentChange(cmp.A.Latencies.Max, cmp.B.Latencies.Max))
+
+	fmt.Fprintf(w, "\nBucket\tA\tB\tΔ%%\tHistogram\n")
+	for _, d := range cmp.Histogram {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.2f%%\t%s\n", d.Bucket, d.A, d.B, d.Percent, sparkline(d.A, d.B))
+	}
+
+	err := w.Flush()
+	return out.Bytes(), err
+}
+
+// JSON renders a Comparison of a and b as JSON.
+func (c *CompareReporter) JSON(a, b Results) ([]byte, error) {
+	return json.Marshal(c.Compare(a, b))
+}
+
+// ANSI escapes used to color sparkline bars red when a bucket's count grew
+// between runs and green when it shrank.
+const (
+	ansiRed   = "\033[31m"
+	ansiGreen = "\033[32m"
+	ansiReset = "\033[0m"
+)
+
+// sparkline renders a pair of bars on one line ("A:... B:...") comparing
+// counts a and b, scaled to the larger of the two and colored by whether
+// the count grew or shrank.
+func sparkline(a, b int) string {
+	max := a
+	if b > max {
+		max = b
+	}
+	if max == 0 {
+		return ""
+	}
+
+	color := ansiGreen
+	if b > a {
+		color = ansiRed
+	}
+
+	const width = 20
+	return fmt.Sprintf("%sA:%s B:%s%s",
+		color,
+		strings.Repeat("#", a*width/max),
+		strings.Repeat("#", b*width/max),
+		ansiReset,
+	)
+}
+
+func durationPercentChange(a, b time.Duration) float64 {
+	if a == 0 {
+		if b == 0 {
+			return 0
+		}
+		return 100
+	}
+	return (float64(b) - float64(a)) / float64(a) * 100
+}
@@ -0,0 +1,68 @@
+package vegeta
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPercentChange(t *testing.T) {
+	cases := []struct {
+		a, b int
+		want float64
+	}{
+		{10, 20, 100},
+		{20, 10, -50},
+		{0, 0, 0},
+		{0, 5, 100},
+		{10, 10, 0},
+	}
+	for _, c := range cases {
+		if got := percentChange(c.a, c.b); got != c.want {
+			t.Errorf("percentChange(%d, %d) = %.2f, want %.2f", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestDurationPercentChange(t *testing.T) {
+	got := durationPercentChange(100*time.Millisecond, 150*time.Millisecond)
+	if got != 50 {
+		t.Errorf("durationPercentChange = %.2f, want 50", got)
+	}
+}
+
+func TestCompareReporterJSON(t *testing.T) {
+	buckets := HistogramReporter{0, 10 * time.Millisecond, 50 * time.Millisecond}
+	cr := NewCompareReporter(buckets)
+	a := Results{{Code: 200, Latency: 10 * time.Millisecond}}
+	b := Results{{Code: 200, Latency: 20 * time.Millisecond}}
+
+	data, err := cr.JSON(a, b)
+	if err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+
+	var cmp Comparison
+	if err := json.Unmarshal(data, &cmp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if cmp.A.Requests != 1 || cmp.B.Requests != 1 {
+		t.Errorf("cmp.A.Requests, cmp.B.Requests = %d, %d, want 1, 1", cmp.A.Requests, cmp.B.Requests)
+	}
+	if len(cmp.Histogram) != len(buckets) {
+		t.Errorf("len(cmp.Histogram) = %d, want %d", len(cmp.Histogram), len(buckets))
+	}
+}
+
+func TestSparklineColorsByDirection(t *testing.T) {
+	grew := sparkline(10, 20)
+	if !strings.Contains(grew, ansiRed) {
+		t.Errorf("sparkline(10, 20) = %q, want it colored red (count grew)", grew)
+	}
+
+	shrank := sparkline(20, 10)
+	if !strings.Contains(shrank, ansiGreen) {
+		t.Errorf("sparkline(20, 10) = %q, want it colored green (count shrank)", shrank)
+	}
+}
@@ -0,0 +1,83 @@
+package vegeta
+
+import (
+	"bytes"
+	"image/color"
+
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/plotter"
+	"gonum.org/v1/plot/vg"
+	"gonum.org/v1/plot/vg/draw"
+	"gonum.org/v1/plot/vg/vgimg"
+)
+
+// colorOK and colorError match the green/red used for OK and error
+// latencies in ReportPlot.
+var (
+	colorOK    = color.RGBA{R: 0x8A, G: 0xE2, B: 0x34, A: 0xff}
+	colorError = color.RGBA{R: 0xFA, G: 0x78, B: 0x78, A: 0xff}
+)
+
+// ReportPNG returns a Reporter that renders the OK/error latency series to
+// a static PNG of the given dimensions using a pure-Go plotting backend,
+// so it can be used in CI or other environments without a browser.
+func ReportPNG(width, height int) ReporterFunc {
+	return func(r Results) ([]byte, error) {
+		p := plot.New()
+		p.Title.Text = "Vegeta Plot"
+		p.X.Label.Text = "Seconds elapsed"
+		p.Y.Label.Text = "Latency (ms)"
+
+		ok, errs := latencySeries(r)
+
+		if len(ok) > 0 {
+			line, err := plotter.NewLine(ok)
+			if err != nil {
+				return nil, err
+			}
+			line.Color = colorOK
+			p.Add(line)
+			p.Legend.Add("OK", line)
+		}
+
+		if len(errs) > 0 {
+			line, err := plotter.NewLine(errs)
+			if err != nil {
+				return nil, err
+			}
+			line.Color = colorError
+			p.Add(line)
+			p.Legend.Add("ERROR", line)
+		}
+
+		c := vgimg.New(vg.Points(float64(width)), vg.Points(float64(height)))
+		p.Draw(draw.New(c))
+
+		var buf bytes.Buffer
+		png := vgimg.PngCanvas{Canvas: c}
+		if _, err := png.WriteTo(&buf); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+}
+
+// latencySeries splits Results into OK and error XY series of elapsed
+// seconds against latency in milliseconds, in request order.
+func latencySeries(r Results) (ok, errs plotter.XYs) {
+	if len(r) == 0 {
+		return nil, nil
+	}
+	for i := range r {
+		pt := plotter.XY{
+			X: r[i].Timestamp.Sub(r[0].Timestamp).Seconds(),
+			Y: r[i].Latency.Seconds() * 1000,
+		}
+		if r[i].Error == "" {
+			ok = append(ok, pt)
+		} else {
+			errs = append(errs, pt)
+		}
+	}
+	return ok, errs
+}
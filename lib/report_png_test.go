@@ -0,0 +1,30 @@
+package vegeta
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencySeriesSplitsOKAndErrors(t *testing.T) {
+	start := time.Now()
+	r := Results{
+		{Timestamp: start, Latency: 10 * time.Millisecond, Error: ""},
+		{Timestamp: start.Add(time.Second), Latency: 20 * time.Millisecond, Error: "boom"},
+		{Timestamp: start.Add(2 * time.Second), Latency: 30 * time.Millisecond, Error: ""},
+	}
+
+	ok, errs := latencySeries(r)
+
+	if len(ok) != 2 {
+		t.Fatalf("len(ok) = %d, want 2", len(ok))
+	}
+	if len(errs) != 1 {
+		t.Fatalf("len(errs) = %d, want 1", len(errs))
+	}
+	if ok[0].X != 0 || ok[0].Y != 10 {
+		t.Errorf("ok[0] = %+v, want {X:0 Y:10}", ok[0])
+	}
+	if errs[0].X != 1 || errs[0].Y != 20 {
+		t.Errorf("errs[0] = %+v, want {X:1 Y:20}", errs[0])
+	}
+}
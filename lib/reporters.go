@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"text/tabwriter"
 	"time"
 )
@@ -111,6 +114,200 @@ var ReportJSON ReporterFunc = func(r Results) ([]byte, error) {
 	return json.Marshal(NewMetrics(r))
 }
 
+// logBuckets is the number of buckets used by SnapshotReporter's streaming
+// latency histogram.
+const logBuckets = 256
+
+// logMin and logMax bound the latency range tracked by the histogram; any
+// latency outside this range is clamped to the nearest edge bucket.
+var (
+	logMin = time.Microsecond
+	logMax = 60 * time.Second
+)
+
+// Snapshot is a point-in-time view of a SnapshotReporter's running metrics,
+// suitable for JSON serialization.
+type Snapshot struct {
+	Elapsed     time.Duration    `json:"elapsed"`
+	Requests    uint64           `json:"requests"`
+	RPS         float64          `json:"rps"`
+	Success     float64          `json:"success"`
+	Latencies   SnapshotLatency  `json:"latencies"`
+	StatusCodes map[string]int64 `json:"status_codes"`
+	Errors      []string         `json:"errors"`
+}
+
+// SnapshotLatency holds the latency percentiles computed from a
+// SnapshotReporter's streaming histogram.
+type SnapshotLatency struct {
+	P50 time.Duration `json:"p50"`
+	P95 time.Duration `json:"p95"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// SnapshotReporter is a Reporter that also maintains running counters as
+// Results are added one at a time, so long-running attacks can report
+// progress without buffering every Result in memory. Its latency
+// percentiles are computed from a fixed-size, log-scaled histogram rather
+// than the full latency set, so memory use is bounded regardless of how
+// long the attack runs.
+type SnapshotReporter struct {
+	mu sync.Mutex
+
+	start    time.Time
+	requests uint64
+	success  uint64
+	buckets  [logBuckets]uint64
+	maxLat   time.Duration
+	codes    map[string]int64
+	errs     map[string]int
+}
+
+// NewSnapshotReporter returns a ready to use SnapshotReporter.
+func NewSnapshotReporter() *SnapshotReporter {
+	return &SnapshotReporter{
+		start: time.Now(),
+		codes: map[string]int64{},
+		errs:  map[string]int{},
+	}
+}
+
+// Add records a single Result in the running counters.
+func (s *SnapshotReporter) Add(r *Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests++
+	if r.Error == "" {
+		s.success++
+	} else {
+		s.errs[r.Error]++
+	}
+
+	s.codes[strconv.Itoa(int(r.Code))]++
+
+	s.buckets[logBucket(r.Latency)]++
+	if r.Latency > s.maxLat {
+		s.maxLat = r.Latency
+	}
+}
+
+// Snapshot returns the current state of the running counters.
+func (s *SnapshotReporter) Snapshot() Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := time.Since(s.start)
+	var rps float64
+	if elapsed > 0 {
+		rps = float64(s.requests) / elapsed.Seconds()
+	}
+	var success float64
+	if s.requests > 0 {
+		success = float64(s.success) / float64(s.requests)
+	}
+
+	codes := make(map[string]int64, len(s.codes))
+	for code, count := range s.codes {
+		codes[code] = count
+	}
+
+	return Snapshot{
+		Elapsed:  elapsed,
+		Requests: s.requests,
+		RPS:      rps,
+		Success:  success,
+		Latencies: SnapshotLatency{
+			P50: logPercentile(s.buckets[:], 0.50),
+			P95: logPercentile(s.buckets[:], 0.95),
+			P99: logPercentile(s.buckets[:], 0.99),
+			Max: s.maxLat,
+		},
+		StatusCodes: codes,
+		Errors:      topErrors(s.errs, 10),
+	}
+}
+
+// Report implements the Reporter interface by taking a final snapshot of
+// the counters and marshaling it as JSON, ignoring the passed in Results.
+func (s *SnapshotReporter) Report(Results) ([]byte, error) {
+	return json.Marshal(s.Snapshot())
+}
+
+// logBucket maps a latency to its bucket index in a histogram covering
+// [logMin, logMax] on a log scale.
+func logBucket(d time.Duration) int {
+	if d <= logMin {
+		return 0
+	}
+	if d >= logMax {
+		return logBuckets - 1
+	}
+	ratio := math.Log(float64(d)/float64(logMin)) / math.Log(float64(logMax)/float64(logMin))
+	i := int(ratio * (logBuckets - 1))
+	if i < 0 {
+		i = 0
+	}
+	if i >= logBuckets {
+		i = logBuckets - 1
+	}
+	return i
+}
+
+// logPercentile estimates the given percentile (0, 1] from a log-scaled
+// latency histogram.
+func logPercentile(buckets []uint64, p float64) time.Duration {
+	var total uint64
+	for _, c := range buckets {
+		total += c
+	}
+	if total == 0 {
+		return 0
+	}
+
+	// Nearest-rank percentile: the target rank is always at least 1, so a
+	// single observed value (total==1) resolves to its own bucket instead
+	// of truncating to 0 and matching the first non-empty bucket seen.
+	rank := uint64(math.Ceil(p * float64(total)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	var cum uint64
+	for i, c := range buckets {
+		cum += c
+		if cum >= rank {
+			ratio := float64(i) / float64(logBuckets-1)
+			return time.Duration(float64(logMin) * math.Pow(float64(logMax)/float64(logMin), ratio))
+		}
+	}
+	return logMax
+}
+
+// topErrors returns up to n of the most frequent errors, most frequent
+// first.
+func topErrors(errs map[string]int, n int) []string {
+	type errCount struct {
+		err   string
+		count int
+	}
+	ec := make([]errCount, 0, len(errs))
+	for err, count := range errs {
+		ec = append(ec, errCount{err, count})
+	}
+	sort.Slice(ec, func(i, j int) bool { return ec[i].count > ec[j].count })
+
+	if len(ec) > n {
+		ec = ec[:n]
+	}
+	out := make([]string, len(ec))
+	for i, e := range ec {
+		out[i] = e.err
+	}
+	return out
+}
+
 // ReportPlot builds up a self contained HTML page with an interactive plot
 // of the latencies of the requests. Built with http://dygraphs.com/
 var ReportPlot ReporterFunc = func(r Results) ([]byte, error) {
@@ -166,7 +363,6 @@ const plotsTemplate = `<!doctype>
 </body>
 </html>`
 
-
 // ReportChart builds up an HTML page with an interactive plot
 // of the latencies of the requests. Built with http://highcharts.com/
 var ReportChart ReporterFunc = func(r Results) ([]byte, error) {
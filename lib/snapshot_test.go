@@ -0,0 +1,23 @@
+package vegeta
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSnapshotReporterPercentileSingleResult(t *testing.T) {
+	s := NewSnapshotReporter()
+	s.Add(&Result{Latency: 5 * time.Second})
+
+	snap := s.Snapshot()
+
+	// With a single sample, every percentile should resolve near the
+	// bucket that latency actually falls into, not collapse to ~logMin
+	// because the nearest-rank target truncated to 0.
+	if snap.Latencies.P50 < time.Second {
+		t.Errorf("P50 = %s, want a value close to the 5s latency observed", snap.Latencies.P50)
+	}
+	if snap.Latencies.P99 < time.Second {
+		t.Errorf("P99 = %s, want a value close to the 5s latency observed", snap.Latencies.P99)
+	}
+}